@@ -0,0 +1,205 @@
+package huma
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BodyCodec marshals and unmarshals request and response bodies for a
+// particular wire format. Implementations are registered on the router via
+// `Router.RegisterCodec` and selected using standard HTTP content
+// negotiation: the request `Content-Type` header picks the decoder used for
+// the input body, while the request `Accept` header picks the encoder used
+// for the response body.
+type BodyCodec interface {
+	// Mime returns the MIME type handled by this codec, e.g.
+	// `application/json`.
+	Mime() string
+
+	// Ext returns the file extension associated with this codec, without
+	// the leading dot, e.g. `json`.
+	Ext() string
+
+	// Marshal encodes v into this codec's wire format.
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes data into v.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// jsonCodec is the default `BodyCodec`. It is always registered first so it
+// remains the fallback when a client sends no `Content-Type`/`Accept`
+// header.
+type jsonCodec struct{}
+
+func (c *jsonCodec) Mime() string { return "application/json" }
+func (c *jsonCodec) Ext() string  { return "json" }
+
+func (c *jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (c *jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// codecRegistry tracks the body codecs registered on a router and implements
+// content negotiation for request decoding and response encoding.
+type codecRegistry struct {
+	codecs []BodyCodec
+}
+
+// newCodecRegistry creates a registry with the default JSON codec already
+// registered.
+func newCodecRegistry() *codecRegistry {
+	r := &codecRegistry{}
+	r.register(&jsonCodec{})
+	return r
+}
+
+func (r *codecRegistry) register(c BodyCodec) {
+	r.codecs = append(r.codecs, c)
+}
+
+// RegisterCodec adds a `BodyCodec` to the router, making it available for
+// both request decoding (via `Content-Type`) and response encoding (via
+// `Accept` negotiation). The OpenAPI generator lists every registered MIME
+// type under `requestBody.content` and each response's `content`.
+func (r *Router) RegisterCodec(c BodyCodec) {
+	r.codecs.register(c)
+}
+
+// mimeTypes returns every registered MIME type, JSON first, in registration
+// order.
+func (r *codecRegistry) mimeTypes() []string {
+	mimes := make([]string, len(r.codecs))
+	for i, c := range r.codecs {
+		mimes[i] = c.Mime()
+	}
+	return mimes
+}
+
+// forContentType returns the codec matching the given `Content-Type`
+// header value, or nil if it doesn't match any registered codec. An empty
+// header defaults to JSON, matching a client that didn't bother setting
+// one, but a recognized-looking header that names an unregistered MIME
+// type is never silently treated as JSON.
+func (r *codecRegistry) forContentType(contentType string) BodyCodec {
+	if contentType == "" {
+		return r.codecs[0]
+	}
+
+	mimeType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mimeType = contentType
+	}
+
+	for _, c := range r.codecs {
+		if c.Mime() == mimeType {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// forAccept performs standard `Accept` header negotiation, including `q`
+// parameters, and returns the best matching codec, defaulting to JSON if
+// nothing matches.
+func (r *codecRegistry) forAccept(accept string) BodyCodec {
+	if accept == "" || accept == "*/*" {
+		return r.codecs[0]
+	}
+
+	type candidate struct {
+		codec   BodyCodec
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mimeType := strings.TrimSpace(fields[0])
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		// A quality of 0 marks the media range as not acceptable.
+		if quality <= 0 {
+			continue
+		}
+
+		if mimeType == "*/*" {
+			candidates = append(candidates, candidate{r.codecs[0], quality})
+			continue
+		}
+
+		for _, c := range r.codecs {
+			if c.Mime() == mimeType {
+				candidates = append(candidates, candidate{c, quality})
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return r.codecs[0]
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].codec
+}
+
+// writeNegotiatedBody marshals v using the codec selected from the
+// request's `Accept` header, sets the response `Content-Type`, writes the
+// given status code, and writes the marshaled body. The status must be
+// written through this call (rather than separately) so `Content-Type` is
+// set before the header is sent.
+func (r *codecRegistry) writeNegotiatedBody(w http.ResponseWriter, req *http.Request, status int, v interface{}) error {
+	codec := r.forAccept(req.Header.Get("Accept"))
+
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", codec.Mime())
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	return err
+}
+
+// readBody reads the raw request body bytes and selects the codec to use
+// for decoding based on `Content-Type`. The raw bytes are always captured
+// verbatim so they can be exposed via the `RawBody []byte` input field
+// regardless of which codec produced them. It returns an error if
+// `Content-Type` doesn't match any registered codec, rather than silently
+// decoding an unrecognized wire format as JSON.
+func (r *codecRegistry) readBody(req *http.Request) ([]byte, BodyCodec, error) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	codec := r.forContentType(req.Header.Get("Content-Type"))
+	if codec == nil {
+		return nil, nil, fmt.Errorf("unsupported content type: %s", req.Header.Get("Content-Type"))
+	}
+
+	return data, codec, nil
+}
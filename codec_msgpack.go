@@ -0,0 +1,34 @@
+package huma
+
+import (
+	"github.com/ugorji/go/codec"
+)
+
+// msgpackHandle is shared across requests, per the recommendation in
+// `github.com/ugorji/go/codec`'s docs that a configured `Handle` be reused
+// rather than constructed per call.
+var msgpackHandle = &codec.MsgpackHandle{}
+
+// msgpackCodec implements `BodyCodec` for `application/msgpack` bodies,
+// the same approach gin's binding layer uses for `binding.MsgPack`.
+type msgpackCodec struct{}
+
+func (c *msgpackCodec) Mime() string { return "application/msgpack" }
+func (c *msgpackCodec) Ext() string  { return "msgpack" }
+
+func (c *msgpackCodec) Marshal(v interface{}) ([]byte, error) {
+	var data []byte
+	err := codec.NewEncoderBytes(&data, msgpackHandle).Encode(v)
+	return data, err
+}
+
+func (c *msgpackCodec) Unmarshal(data []byte, v interface{}) error {
+	return codec.NewDecoderBytes(data, msgpackHandle).Decode(v)
+}
+
+// RegisterMsgpackCodec registers the MessagePack `BodyCodec` on the router
+// so that, alongside the default JSON codec, clients can send and receive
+// `application/msgpack` request and response bodies.
+func RegisterMsgpackCodec(r *Router) {
+	r.RegisterCodec(&msgpackCodec{})
+}
@@ -0,0 +1,79 @@
+package huma
+
+import (
+	"net/http"
+)
+
+// Context carries request-scoped state through to handlers and lets them
+// report errors and write responses.
+type Context interface {
+	http.ResponseWriter
+
+	// Request returns the underlying *http.Request.
+	Request() *http.Request
+
+	// AddError appends a field-level error to be included in the
+	// consolidated `ErrorModel` response once input processing finishes.
+	AddError(err *ErrorDetail)
+
+	// Errors returns every error added via AddError so far.
+	Errors() []*ErrorDetail
+
+	// WriteError writes a complete ErrorModel response with the given
+	// status and detail message, including any errors added via AddError.
+	WriteError(status int, detail string, errs ...error)
+}
+
+// context is the router's default Context implementation.
+type context struct {
+	http.ResponseWriter
+	r      *http.Request
+	router *Router
+	errs   []*ErrorDetail
+}
+
+func newContext(router *Router, w http.ResponseWriter, r *http.Request) *context {
+	return &context{ResponseWriter: w, r: r, router: router}
+}
+
+func (c *context) Request() *http.Request {
+	return c.r
+}
+
+func (c *context) AddError(err *ErrorDetail) {
+	c.errs = append(c.errs, err)
+}
+
+func (c *context) Errors() []*ErrorDetail {
+	return c.errs
+}
+
+func (c *context) WriteError(status int, detail string, errs ...error) {
+	details := append([]*ErrorDetail{}, c.errs...)
+	for _, err := range errs {
+		details = append(details, &ErrorDetail{Message: err.Error()})
+	}
+
+	writeErrorModel(c.router, c, status, detail, details)
+}
+
+// schemaURL builds the `$schema` link included on every ErrorModel
+// response, e.g. `https://example.com/schemas/ErrorModel.json`.
+func schemaURL(r *http.Request, name string) string {
+	return "https://" + r.Host + "/schemas/" + name + ".json"
+}
+
+// writeErrorModel writes an ErrorModel response, picking the response
+// codec via the router's registered BodyCodecs and the request's `Accept`
+// header, the same negotiation any other response body goes through.
+func writeErrorModel(router *Router, ctx Context, status int, detail string, details []*ErrorDetail) {
+	model := &ErrorModel{
+		Schema: schemaURL(ctx.Request(), "ErrorModel"),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Errors: details,
+	}
+
+	router.codecs.writeNegotiatedBody(ctx, ctx.Request(), status, model)
+}
@@ -0,0 +1,27 @@
+package huma
+
+// ContextFactory lets callers wrap the router's default `Context`
+// implementation with their own, the same way gin lets callers swap in a
+// custom context type. It's invoked once per request with the router's
+// base `Context` and must return a value that still satisfies `Context`,
+// typically by embedding base and adding request-scoped data (tenant,
+// tracing span, feature flags, ...) or typed helpers on top of
+// `AddError`/`WriteError`.
+type ContextFactory func(base Context) Context
+
+// RegisterContextFactory installs a `ContextFactory` on the router. Every
+// handler registered via `Operation.Run` subsequently receives the wrapped
+// `Context` returned by fn instead of the router's default implementation.
+func (r *Router) RegisterContextFactory(fn ContextFactory) {
+	r.contextFactory = fn
+}
+
+// wrapContext applies the router's `ContextFactory` to base, if one is
+// registered, and returns base unchanged otherwise.
+func (r *Router) wrapContext(base Context) Context {
+	if r.contextFactory == nil {
+		return base
+	}
+
+	return r.contextFactory(base)
+}
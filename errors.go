@@ -0,0 +1,21 @@
+package huma
+
+// ErrorDetail provides field-level detail about a single failure
+// encountered while processing a request, e.g. one failed validation.
+type ErrorDetail struct {
+	Message  string      `json:"message"`
+	Location string      `json:"location,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+}
+
+// ErrorModel is the response body written by `Context.WriteError` and for
+// the 422 returned when input processing fails, encoded with the codec
+// picked by `Accept` negotiation. The bare 400 for an undecodable body and
+// the router's 404 carry no ErrorModel.
+type ErrorModel struct {
+	Schema string         `json:"$schema,omitempty"`
+	Title  string         `json:"title"`
+	Status int            `json:"status"`
+	Detail string         `json:"detail,omitempty"`
+	Errors []*ErrorDetail `json:"errors,omitempty"`
+}
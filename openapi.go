@@ -0,0 +1,140 @@
+package huma
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// MediaTypes is the set of MIME types available under a `requestBody` or
+// response's `content` in an OpenAPI document, one entry per BodyCodec
+// registered on the router.
+type MediaTypes map[string]struct{}
+
+// RequestBodyObject documents the content types accepted for an operation's
+// request body. Validate lists every field (by location, e.g. `body.id`)
+// that carries a `validate` struct tag, keyed to that tag's raw value, so
+// generated docs and client tooling can see which custom validators apply.
+type RequestBodyObject struct {
+	Content  MediaTypes        `json:"content"`
+	Validate map[string]string `json:"x-validate,omitempty"`
+}
+
+// ResponseObject documents a single response an operation may return.
+type ResponseObject struct {
+	Description string     `json:"description"`
+	Content     MediaTypes `json:"content,omitempty"`
+}
+
+// PathItem documents the operations registered at a single path.
+type PathItem map[string]*OperationObject
+
+// OperationObject documents a single method registered on a path.
+type OperationObject struct {
+	OperationID string                     `json:"operationId"`
+	Description string                     `json:"description"`
+	RequestBody *RequestBodyObject         `json:"requestBody,omitempty"`
+	Responses   map[string]*ResponseObject `json:"responses"`
+}
+
+// OpenAPI is the root of the generated OpenAPI document.
+type OpenAPI struct {
+	Paths map[string]PathItem `json:"paths"`
+}
+
+// OpenAPI generates an OpenAPI document describing every operation
+// registered on the router, listing every registered BodyCodec's MIME type
+// under each operation's `requestBody.content` (if it accepts a body) and
+// each of its declared responses' `content`.
+func (r *Router) OpenAPI() *OpenAPI {
+	doc := &OpenAPI{Paths: map[string]PathItem{}}
+
+	for _, op := range r.operations {
+		item, ok := doc.Paths[op.resource.path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[op.resource.path] = item
+		}
+
+		obj := &OperationObject{
+			OperationID: op.id,
+			Description: op.docs,
+			Responses:   map[string]*ResponseObject{},
+		}
+
+		if bodyField, ok := bodyFieldType(op.inputType); ok {
+			obj.RequestBody = &RequestBodyObject{
+				Content:  r.mediaTypes(),
+				Validate: validateExtensions(bodyField.Type, "body"),
+			}
+		}
+
+		for _, resp := range op.responses {
+			obj.Responses[strconv.Itoa(resp.status)] = &ResponseObject{
+				Description: resp.description,
+				Content:     r.mediaTypes(),
+			}
+		}
+
+		item[op.method] = obj
+	}
+
+	return doc
+}
+
+// mediaTypes builds a MediaTypes set from every BodyCodec registered on the
+// router.
+func (r *Router) mediaTypes() MediaTypes {
+	types := MediaTypes{}
+	for _, mime := range r.codecs.mimeTypes() {
+		types[mime] = struct{}{}
+	}
+	return types
+}
+
+func bodyFieldType(t reflect.Type) (reflect.StructField, bool) {
+	if t == nil {
+		return reflect.StructField{}, false
+	}
+	return t.FieldByName("Body")
+}
+
+// validateExtensions walks t (assumed to be a request body type) the same
+// way runValidators walks a decoded value, collecting every field carrying
+// a `validate` struct tag into a location -> tag map suitable for the
+// `x-validate` OpenAPI extension.
+func validateExtensions(t reflect.Type, location string) map[string]string {
+	out := map[string]string{}
+	collectValidateExtensions(t, location, out)
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func collectValidateExtensions(t reflect.Type, location string, out map[string]string) {
+	if t == nil {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		collectValidateExtensions(t.Elem(), location, out)
+	case reflect.Slice, reflect.Array:
+		collectValidateExtensions(t.Elem(), location+"[]", out)
+	case reflect.Map:
+		collectValidateExtensions(t.Elem(), location+".*", out)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			name, _ := jsonFieldName(field)
+			fieldLoc := location + "." + name
+
+			if tag := field.Tag.Get("validate"); tag != "" {
+				out[fieldLoc] = tag
+			}
+
+			collectValidateExtensions(field.Type, fieldLoc, out)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package huma
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPIMediaTypes(t *testing.T) {
+	app := newTestRouter()
+
+	app.Resource("/items").Post("create-item", "Create an item",
+		NewResponse(http.StatusNoContent, "desc"),
+	).Run(func(ctx Context, input struct {
+		Body struct {
+			Test int `json:"test"`
+		}
+	}) {
+		// Do nothing
+	})
+
+	doc := app.OpenAPI()
+
+	op := doc.Paths["/items"][http.MethodPost]
+	assert.True(t, op != nil)
+
+	if _, ok := op.RequestBody.Content["application/json"]; !ok {
+		t.Fatal("expected application/json under requestBody.content")
+	}
+	if _, ok := op.RequestBody.Content["application/msgpack"]; !ok {
+		t.Fatal("expected application/msgpack under requestBody.content")
+	}
+
+	resp := op.Responses["204"]
+	assert.True(t, resp != nil)
+
+	if _, ok := resp.Content["application/json"]; !ok {
+		t.Fatal("expected application/json under the 204 response's content")
+	}
+	if _, ok := resp.Content["application/msgpack"]; !ok {
+		t.Fatal("expected application/msgpack under the 204 response's content")
+	}
+}
+
+func TestOpenAPIValidateExtension(t *testing.T) {
+	app := newTestRouter()
+	app.RegisterValidator("uuid", func(value interface{}, param string) error {
+		return nil
+	})
+
+	app.Resource("/items").Post("create-item", "Create an item").Run(func(ctx Context, input struct {
+		Body struct {
+			Test int    `json:"test" minimum:"5"`
+			ID   string `json:"id" validate:"uuid"`
+		}
+	}) {
+		// Do nothing
+	})
+
+	doc := app.OpenAPI()
+
+	op := doc.Paths["/items"][http.MethodPost]
+	assert.True(t, op != nil)
+	assert.Equal(t, map[string]string{"body.id": "uuid"}, op.RequestBody.Validate)
+}
+
+func TestOpenAPINoRequestBody(t *testing.T) {
+	app := newTestRouter()
+
+	app.Resource("/ping").Get("ping", "Ping").Run(func(ctx Context, input struct{}) {
+		// Do nothing
+	})
+
+	doc := app.OpenAPI()
+
+	op := doc.Paths["/ping"][http.MethodGet]
+	assert.True(t, op != nil)
+
+	if op.RequestBody != nil {
+		t.Fatal("expected no requestBody for an operation with no Body field")
+	}
+}
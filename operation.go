@@ -0,0 +1,120 @@
+package huma
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// Operation represents a single HTTP method handler registered under a
+// Resource.
+type Operation struct {
+	resource  *Resource
+	method    string
+	id        string
+	docs      string
+	responses []*Response
+
+	inputType reflect.Type
+	handler   reflect.Value
+}
+
+// Run registers handler, which must have the signature
+// `func(ctx Context, input <Input>)`, to run whenever this operation is
+// invoked.
+func (o *Operation) Run(handler interface{}) {
+	o.handler = reflect.ValueOf(handler)
+	o.inputType = o.handler.Type().In(1)
+
+	o.resource.router.addOperation(o)
+}
+
+// dispatch parses and validates a request's input, then -- provided no
+// errors were found -- invokes the operation's handler.
+func (o *Operation) dispatch(w http.ResponseWriter, req *http.Request) {
+	router := o.resource.router
+	ctx := router.wrapContext(newContext(router, w, req))
+
+	inputPtr := reflect.New(o.inputType)
+	input := inputPtr.Elem()
+
+	paramErrs := parseQueryParams(req, input)
+
+	bodyErrs, badRequest := decodeBody(router, ctx, req, input)
+	if badRequest {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	allErrs := append(paramErrs, bodyErrs...)
+
+	if len(allErrs) > 0 {
+		writeErrorModel(router, ctx, http.StatusUnprocessableEntity, "Error while processing input parameters", allErrs)
+		return
+	}
+
+	o.handler.Call([]reflect.Value{reflect.ValueOf(ctx), input})
+}
+
+func isByteSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// decodeBody reads and decodes the request body, if input declares a Body
+// field, capturing RawBody verbatim, then runs Sanitize, checks the simple
+// schema keywords this package supports, runs any registered `validate` tag
+// validators, and finally runs Resolve against the decoded value. It
+// returns the validation errors found, in the order they were produced,
+// plus whether the body was malformed enough to warrant an immediate 400
+// response instead of a 422 with field-level errors.
+func decodeBody(router *Router, ctx Context, req *http.Request, input reflect.Value) ([]*ErrorDetail, bool) {
+	inputType := input.Type()
+
+	bodyField, hasBody := inputType.FieldByName("Body")
+	if !hasBody {
+		return nil, false
+	}
+
+	data, codec, err := router.codecs.readBody(req)
+	if err != nil {
+		return nil, true
+	}
+
+	if rawField, ok := inputType.FieldByName("RawBody"); ok && isByteSlice(rawField.Type) {
+		input.FieldByName("RawBody").SetBytes(data)
+	}
+
+	if len(data) == 0 {
+		return nil, false
+	}
+
+	bodyValue := input.FieldByName("Body")
+
+	if err := codec.Unmarshal(data, bodyValue.Addr().Interface()); err != nil {
+		return nil, true
+	}
+
+	var errs []*ErrorDetail
+
+	before := len(ctx.Errors())
+	runSanitizers(ctx, req, bodyValue, "body")
+	errs = append(errs, ctx.Errors()[before:]...)
+
+	if bodyValue.Kind() == reflect.Struct {
+		var raw map[string]interface{}
+		if err := codec.Unmarshal(data, &raw); err == nil {
+			errs = append(errs, requiredFieldErrors(raw, bodyField.Type, "body")...)
+		}
+
+		errs = append(errs, schemaConstraintErrors(bodyValue, "body")...)
+	}
+
+	before = len(ctx.Errors())
+	runValidators(ctx, router.validators, bodyValue, "body")
+	errs = append(errs, ctx.Errors()[before:]...)
+
+	before = len(ctx.Errors())
+	runResolvers(ctx, req, bodyValue, "body")
+	errs = append(errs, ctx.Errors()[before:]...)
+
+	return errs, false
+}
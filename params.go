@@ -0,0 +1,144 @@
+package huma
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// parseQueryParams parses every `query:"..."` tagged field of input (in
+// struct field order) from the request's query string, returning one
+// ErrorDetail per field that failed to parse.
+func parseQueryParams(req *http.Request, input reflect.Value) []*ErrorDetail {
+	var errs []*ErrorDetail
+
+	query := req.URL.Query()
+	t := input.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("query")
+		if tag == "" {
+			continue
+		}
+
+		values, ok := query[tag]
+		fieldValue := input.Field(i)
+		location := "query." + tag
+
+		switch {
+		case field.Type.Kind() == reflect.Bool:
+			if !ok {
+				continue
+			}
+			if len(values) == 0 || values[0] == "" {
+				fieldValue.SetBool(true)
+				continue
+			}
+			parsed, err := strconv.ParseBool(values[0])
+			if err != nil {
+				errs = append(errs, &ErrorDetail{Message: "cannot parse boolean", Location: location, Value: values[0]})
+				continue
+			}
+			fieldValue.SetBool(parsed)
+
+		case field.Type.Kind() == reflect.Int:
+			if !ok || len(values) == 0 {
+				continue
+			}
+			parsed, err := strconv.Atoi(values[0])
+			if err != nil {
+				errs = append(errs, &ErrorDetail{Message: "cannot parse integer", Location: location, Value: values[0]})
+				continue
+			}
+			fieldValue.SetInt(int64(parsed))
+
+		case field.Type.Kind() == reflect.Float32 || field.Type.Kind() == reflect.Float64:
+			if !ok || len(values) == 0 {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(values[0], 64)
+			if err != nil {
+				errs = append(errs, &ErrorDetail{Message: "cannot parse float", Location: location, Value: values[0]})
+				continue
+			}
+			fieldValue.SetFloat(parsed)
+
+		case field.Type == timeType:
+			if !ok || len(values) == 0 {
+				continue
+			}
+			parsed, err := time.Parse(time.RFC3339, values[0])
+			if err != nil {
+				errs = append(errs, &ErrorDetail{Message: "cannot parse time", Location: location, Value: values[0]})
+				continue
+			}
+			fieldValue.Set(reflect.ValueOf(parsed))
+
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Int:
+			if !ok || len(values) == 0 {
+				continue
+			}
+			errs = append(errs, parseIntSliceParam(fieldValue, tag, values[0])...)
+
+		case field.Type.Kind() == reflect.String:
+			if !ok || len(values) == 0 {
+				continue
+			}
+			fieldValue.SetString(values[0])
+		}
+	}
+
+	return errs
+}
+
+// parseIntSliceParam parses a comma-separated list of integers, e.g.
+// `tags=1,2,3`. Every element that fails to parse gets its own error
+// (located at `query[<index>].<field>`), and the whole list is additionally
+// validated as a JSON array literal so malformed input is reported the
+// same way JSON Schema validation would report it.
+func parseIntSliceParam(fieldValue reflect.Value, tag, raw string) []*ErrorDetail {
+	var errs []*ErrorDetail
+
+	parts := strings.Split(raw, ",")
+	parsed := make([]int, 0, len(parts))
+
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			errs = append(errs, &ErrorDetail{
+				Message:  "cannot parse integer",
+				Location: fmt.Sprintf("query[%d].%s", i, tag),
+				Value:    part,
+			})
+			continue
+		}
+		parsed = append(parsed, v)
+	}
+
+	bracketed := "[" + raw + "]"
+	var generic interface{}
+	if err := json.Unmarshal([]byte(bracketed), &generic); err != nil {
+		errs = append(errs, &ErrorDetail{
+			Message:  "unable to validate against schema: " + err.Error(),
+			Location: "query." + tag,
+			Value:    bracketed,
+		})
+	}
+
+	if len(errs) == 0 {
+		slice := reflect.MakeSlice(fieldValue.Type(), len(parsed), len(parsed))
+		for i, v := range parsed {
+			slice.Index(i).SetInt(int64(v))
+		}
+		fieldValue.Set(slice)
+	}
+
+	return errs
+}
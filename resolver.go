@@ -0,0 +1,87 @@
+package huma
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// Resolver is implemented by a request body type (or any struct nested
+// within one) that needs to run custom logic — typically cross-field
+// checks that can't be expressed as a JSON Schema keyword — once the body
+// has been decoded and validated, as used by `Dep1`/`Dep2` in
+// resolver_test.go.
+type Resolver interface {
+	Resolve(ctx Context, r *http.Request)
+}
+
+// runResolvers recursively walks value looking for anything implementing
+// Resolver and invokes it: pointers/interfaces are dereferenced,
+// slices/arrays and maps are walked element by element, and structs are
+// resolved and then recursed into field by field, so nested shapes like
+// `Dep2.Foo map[string][]*Dep1` are fully covered. location tracks the
+// path to value (e.g. `body.foo.a[0]`); any errors a nested Resolve call
+// adds via AddError are prefixed with that path, so a Resolve method can
+// set a bare field-relative Location like "one" and still have it surface
+// as "body.foo.a[0].one" in the response.
+func runResolvers(ctx Context, r *http.Request, value reflect.Value, location string) {
+	if !value.IsValid() {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		runResolvers(ctx, r, value.Elem(), location)
+		return
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			runResolvers(ctx, r, value.Index(i), fmt.Sprintf("%s[%d]", location, i))
+		}
+		return
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			runResolvers(ctx, r, value.MapIndex(key), location+"."+mapKeyString(key))
+		}
+		return
+	case reflect.Struct:
+		// Handled below, then recursed into.
+	default:
+		return
+	}
+
+	if value.CanAddr() {
+		if res, ok := value.Addr().Interface().(Resolver); ok {
+			before := len(ctx.Errors())
+			res.Resolve(ctx, r)
+			prefixNewErrorLocations(ctx, before, location)
+		}
+	} else if res, ok := value.Interface().(Resolver); ok {
+		before := len(ctx.Errors())
+		res.Resolve(ctx, r)
+		prefixNewErrorLocations(ctx, before, location)
+	}
+
+	t := value.Type()
+	for i := 0; i < value.NumField(); i++ {
+		name, _ := jsonFieldName(t.Field(i))
+		runResolvers(ctx, r, value.Field(i), location+"."+name)
+	}
+}
+
+// prefixNewErrorLocations prepends location to the Location of every error
+// added to ctx at index startIdx or later. Shared by runResolvers and
+// runSanitizers, which both let a nested hook set a bare, field-relative
+// Location and have the recursion translate it into a full path.
+func prefixNewErrorLocations(ctx Context, startIdx int, location string) {
+	errs := ctx.Errors()
+	for i := startIdx; i < len(errs); i++ {
+		if errs[i].Location == "" {
+			errs[i].Location = location
+		} else {
+			errs[i].Location = location + "." + errs[i].Location
+		}
+	}
+}
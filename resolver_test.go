@@ -1,7 +1,9 @@
 package huma
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -38,6 +40,43 @@ func TestExhaustiveErrors(t *testing.T) {
 	assert.JSONEq(t, `{"$schema": "https://example.com/schemas/ErrorModel.json", "title":"Unprocessable Entity","status":422,"detail":"Error while processing input parameters","errors":[{"message":"cannot parse boolean","location":"query.bool","value":"bad"},{"message":"cannot parse integer","location":"query.int","value":"bad"},{"message":"cannot parse float","location":"query.float32","value":"bad"},{"message":"cannot parse float","location":"query.float64","value":"bad"},{"message":"cannot parse integer","location":"query[2].tags","value":"bad"},{"message":"unable to validate against schema: invalid character 'b' looking for beginning of value","location":"query.tags","value":"[1,2,bad]"},{"message":"cannot parse time","location":"query.time","value":"bad"},{"message":"Must be greater than or equal to 5","location":"body.test","value":1}]}`, w.Body.String())
 }
 
+func TestExhaustiveErrorsWithCustomValidator(t *testing.T) {
+	app := newTestRouter()
+
+	app.RegisterValidator("uuid", func(value interface{}, param string) error {
+		s, _ := value.(string)
+		if len(s) != 36 {
+			return fmt.Errorf("must be a valid UUID")
+		}
+		return nil
+	})
+
+	app.Resource("/").Post("test", "Test").Run(func(ctx Context, input struct {
+		Body struct {
+			Test int    `json:"test" minimum:"5"`
+			ID   string `json:"id" validate:"uuid"`
+		}
+	}) {
+		// Do nothing
+	})
+
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"test": 1, "id": "not-a-uuid"}`))
+	r.Host = "example.com"
+	app.ServeHTTP(w, r)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://example.com/schemas/ErrorModel.json",
+		"title":"Unprocessable Entity",
+		"status":422,
+		"detail":"Error while processing input parameters",
+		"errors":[
+			{"message":"Must be greater than or equal to 5","location":"body.test","value":1},
+			{"message":"must be a valid UUID","location":"body.id","value":"not-a-uuid"}
+		]
+	}`, w.Body.String())
+}
+
 type Dep1 struct {
 	// Only *one* of the following two may be set.
 	One string `json:"one,omitempty"`
@@ -124,23 +163,152 @@ func TestNestedResolverError(t *testing.T) {
 	}`, w.Body.String())
 }
 
-func TestInvalidJSON(t *testing.T) {
+type tenantContext struct {
+	Context
+	tenantID string
+}
+
+func (c *tenantContext) TenantID() string {
+	return c.tenantID
+}
+
+func TestContextFactory(t *testing.T) {
 	app := newTestRouter()
 
+	app.RegisterContextFactory(func(base Context) Context {
+		return &tenantContext{Context: base, tenantID: "acme"}
+	})
+
 	app.Resource("/").Post("test", "Test",
 		NewResponse(http.StatusNoContent, "desc"),
 	).Run(func(ctx Context, input struct {
-		Body string
+		Body Dep2
 	}) {
+		tc, ok := ctx.(*tenantContext)
+		assert.True(t, ok)
+		assert.Equal(t, "acme", tc.TenantID())
 		ctx.WriteHeader(http.StatusNoContent)
 	})
 
-	// Test happy case just sending ONE of the two possible fields in each struct.
+	// Happy case, including a nested Dep1.Resolve call, with the factory
+	// in place.
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+		"foo": {
+			"a": [{"one": "1"}]
+		}
+	}`))
+	app.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusNoContent, w.Result().StatusCode)
+}
+
+func TestContextFactoryWithNestedResolverError(t *testing.T) {
+	app := newTestRouter()
+
+	app.RegisterContextFactory(func(base Context) Context {
+		return &tenantContext{Context: base, tenantID: "acme"}
+	})
+
+	app.Resource("/").Post("test", "Test",
+		NewResponse(http.StatusNoContent, "desc"),
+	).Run(func(ctx Context, input struct {
+		Body Dep2
+	}) {
+		ctx.WriteHeader(http.StatusNoContent)
+	})
+
+	// The wrapped Context must still flow through to Dep1.Resolve and its
+	// errors must still surface in the usual ErrorModel shape.
 	w := httptest.NewRecorder()
-	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{.2asdf2`))
+	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+		"foo": {
+			"a": [
+				{"one": "1", "two": "2"}
+			]
+		}
+	}`))
+	r.Host = "example.com"
 	app.ServeHTTP(w, r)
 
-	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.JSONEq(t, `{
+		"$schema": "https://example.com/schemas/ErrorModel.json",
+		"status": 422,
+		"title": "Unprocessable Entity",
+		"detail": "Error while processing input parameters",
+		"errors": [
+			{
+				"message": "Only one of ['one', 'two'] is allowed.",
+				"location": "body.foo.a[0].one",
+				"value": "1"
+			}
+		]
+	}`, w.Body.String())
+}
+
+func TestInvalidJSON(t *testing.T) {
+	// Garbage bytes that each codec should fail to decode.
+	invalid := map[string]string{
+		(&jsonCodec{}).Mime():    `{.2asdf2`,
+		(&msgpackCodec{}).Mime(): "\xc1\xc1\xc1",
+	}
+
+	for _, codec := range []BodyCodec{&jsonCodec{}, &msgpackCodec{}} {
+		t.Run(codec.Mime(), func(t *testing.T) {
+			app := newTestRouter()
+
+			app.Resource("/").Post("test", "Test",
+				NewResponse(http.StatusNoContent, "desc"),
+			).Run(func(ctx Context, input struct {
+				Body string
+			}) {
+				ctx.WriteHeader(http.StatusNoContent)
+			})
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(invalid[codec.Mime()]))
+			r.Header.Set("Content-Type", codec.Mime())
+			app.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		})
+	}
+}
+
+func TestInvalidFieldAcrossCodecs(t *testing.T) {
+	// Same schema violation as TestExhaustiveErrors, but decoded through
+	// each registered codec to make sure the resulting 422 ErrorModel is
+	// identical regardless of wire format.
+	for _, codec := range []BodyCodec{&jsonCodec{}, &msgpackCodec{}} {
+		t.Run(codec.Mime(), func(t *testing.T) {
+			app := newTestRouter()
+
+			app.Resource("/").Post("test", "Test").Run(func(ctx Context, input struct {
+				Body struct {
+					Test int `json:"test" minimum:"5"`
+				}
+			}) {
+				// Do nothing
+			})
+
+			body, err := codec.Marshal(map[string]interface{}{"test": 1})
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			r.Header.Set("Content-Type", codec.Mime())
+			r.Host = "example.com"
+			app.ServeHTTP(w, r)
+
+			assert.JSONEq(t, `{
+				"$schema": "https://example.com/schemas/ErrorModel.json",
+				"title":"Unprocessable Entity",
+				"status":422,
+				"detail":"Error while processing input parameters",
+				"errors":[{"message":"Must be greater than or equal to 5","location":"body.test","value":1}]
+			}`, w.Body.String())
+		})
+	}
 }
 
 type QueryParamTestModel struct {
@@ -328,35 +496,181 @@ func TestStringQueryEmpty(t *testing.T) {
 	assert.Equal(t, o.OtherParam, "")
 }
 
-func TestRawBody(t *testing.T) {
+type SanitizeDep struct {
+	Name string `json:"name"`
+	Age  int    `json:"age" minimum:"0"`
+}
+
+func (d *SanitizeDep) Sanitize(ctx Context, r *http.Request) {
+	d.Name = strings.ToLower(strings.TrimSpace(d.Name))
+
+	if d.Name == "forbidden" {
+		ctx.AddError(&ErrorDetail{
+			Message:  "This name is not allowed.",
+			Location: "name",
+			Value:    d.Name,
+		})
+	}
+}
+
+type SanitizeDep2 struct {
+	// Test recursive sanitizer with the same complex input structure used
+	// to test recursive resolvers above: a map of lists of struct pointers.
+	Foo map[string][]*SanitizeDep `json:"foo"`
+}
+
+func TestSanitizeHook(t *testing.T) {
 	app := newTestRouter()
 
-	app.Resource("/").Get("test", "Test",
+	app.Resource("/").Post("test", "Test",
 		NewResponse(http.StatusOK, "desc"),
 	).Run(func(ctx Context, input struct {
-		Body struct {
-			Name string   `json:"name"`
-			Tags []string `json:"tags"`
-		}
-		RawBody []byte
+		Body SanitizeDep
 	}) {
-		ctx.Write(input.RawBody)
+		ctx.Write([]byte(input.Body.Name))
 	})
 
-	// Note the weird formatting
-	body := `{  "name" : "Huma","tags": [ "one"  ,"two"]}`
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "  HUMA  ", "age": 1}`))
+	app.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, "huma", w.Body.String())
+}
+
+func TestSanitizeNested(t *testing.T) {
+	app := newTestRouter()
+
+	app.Resource("/").Post("test", "Test",
+		NewResponse(http.StatusOK, "desc"),
+	).Run(func(ctx Context, input struct {
+		Body SanitizeDep2
+	}) {
+		ctx.Write([]byte(input.Body.Foo["a"][0].Name))
+	})
 
 	w := httptest.NewRecorder()
-	r, _ := http.NewRequest(http.MethodGet, "/", strings.NewReader(body))
+	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+		"foo": {
+			"a": [{"name": "  HUMA  ", "age": 1}]
+		}
+	}`))
 	app.ServeHTTP(w, r)
 
 	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
-	assert.Equal(t, body, w.Body.String())
+	assert.Equal(t, "huma", w.Body.String())
+}
+
+func TestSanitizeNestedError(t *testing.T) {
+	app := newTestRouter()
+
+	app.Resource("/").Post("test", "Test",
+		NewResponse(http.StatusNoContent, "desc"),
+	).Run(func(ctx Context, input struct {
+		Body SanitizeDep2
+	}) {
+		ctx.WriteHeader(http.StatusNoContent)
+	})
+
+	// Same map-of-slices shape as TestNestedResolverError, but the error
+	// comes from a Sanitize call nested inside it: the Location it sets
+	// should come out prefixed with the full path to the nested value.
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+		"foo": {
+			"a": [
+				{"name": "forbidden", "age": 1}
+			]
+		}
+	}`))
+	r.Host = "example.com"
+	app.ServeHTTP(w, r)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://example.com/schemas/ErrorModel.json",
+		"status": 422,
+		"title": "Unprocessable Entity",
+		"detail": "Error while processing input parameters",
+		"errors": [
+			{
+				"message": "This name is not allowed.",
+				"location": "body.foo.a[0].name",
+				"value": "forbidden"
+			}
+		]
+	}`, w.Body.String())
+}
+
+func TestSanitizeErrorConsolidatedWithValidation(t *testing.T) {
+	app := newTestRouter()
 
-	// Invalid input should still fail validation!
-	w = httptest.NewRecorder()
-	r, _ = http.NewRequest(http.MethodGet, "/", strings.NewReader("{}"))
+	app.Resource("/").Post("test", "Test").Run(func(ctx Context, input struct {
+		Body SanitizeDep
+	}) {
+		// Do nothing
+	})
+
+	// A sanitize error (forbidden name) and a schema validation error
+	// (negative age) should both show up in the same response.
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name": "Forbidden", "age": -1}`))
+	r.Host = "example.com"
 	app.ServeHTTP(w, r)
 
-	assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+	assert.JSONEq(t, `{
+		"$schema": "https://example.com/schemas/ErrorModel.json",
+		"title":"Unprocessable Entity",
+		"status":422,
+		"detail":"Error while processing input parameters",
+		"errors":[
+			{"message":"This name is not allowed.","location":"body.name","value":"forbidden"},
+			{"message":"Must be greater than or equal to 0","location":"body.age","value":-1}
+		]
+	}`, w.Body.String())
+}
+
+func TestRawBody(t *testing.T) {
+	for _, codec := range []BodyCodec{&jsonCodec{}, &msgpackCodec{}} {
+		t.Run(codec.Mime(), func(t *testing.T) {
+			app := newTestRouter()
+
+			app.Resource("/").Get("test", "Test",
+				NewResponse(http.StatusOK, "desc"),
+			).Run(func(ctx Context, input struct {
+				Body struct {
+					Name string   `json:"name"`
+					Tags []string `json:"tags"`
+				}
+				RawBody []byte
+			}) {
+				ctx.Write(input.RawBody)
+			})
+
+			// RawBody must capture the exact on-wire bytes regardless of codec.
+			body, err := codec.Marshal(map[string]interface{}{
+				"name": "Huma",
+				"tags": []string{"one", "two"},
+			})
+			assert.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest(http.MethodGet, "/", bytes.NewReader(body))
+			r.Header.Set("Content-Type", codec.Mime())
+			app.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+			assert.Equal(t, body, w.Body.Bytes())
+
+			// Invalid input should still fail validation!
+			empty, err := codec.Marshal(map[string]interface{}{})
+			assert.NoError(t, err)
+
+			w = httptest.NewRecorder()
+			r, _ = http.NewRequest(http.MethodGet, "/", bytes.NewReader(empty))
+			r.Header.Set("Content-Type", codec.Mime())
+			app.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Result().StatusCode)
+		})
+	}
 }
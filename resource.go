@@ -0,0 +1,41 @@
+package huma
+
+import "net/http"
+
+// Response declares a response an operation may return, for documentation
+// purposes; it does not itself affect request handling.
+type Response struct {
+	status      int
+	description string
+}
+
+// NewResponse declares a response with the given status code and
+// description, to be passed to an operation builder like `Resource.Get`.
+func NewResponse(status int, description string) *Response {
+	return &Response{status: status, description: description}
+}
+
+// Resource groups the operations registered under a single path.
+type Resource struct {
+	router *Router
+	path   string
+}
+
+// Resource starts building the set of operations available at path.
+func (r *Router) Resource(path string) *Resource {
+	return &Resource{router: r, path: path}
+}
+
+func (res *Resource) newOperation(method, id, docs string, responses ...*Response) *Operation {
+	return &Operation{resource: res, method: method, id: id, docs: docs, responses: responses}
+}
+
+// Get registers a GET operation on the resource.
+func (res *Resource) Get(id, docs string, responses ...*Response) *Operation {
+	return res.newOperation(http.MethodGet, id, docs, responses...)
+}
+
+// Post registers a POST operation on the resource.
+func (res *Resource) Post(id, docs string, responses ...*Response) *Operation {
+	return res.newOperation(http.MethodPost, id, docs, responses...)
+}
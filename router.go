@@ -0,0 +1,52 @@
+package huma
+
+import "net/http"
+
+// Router is the entry point for building a Huma API: register resources
+// and operations, attach pluggable hooks like BodyCodecs, then serve it
+// like any other `http.Handler`.
+type Router struct {
+	routes         map[string]map[string]*Operation
+	operations     []*Operation
+	codecs         *codecRegistry
+	validators     *validatorRegistry
+	contextFactory ContextFactory
+}
+
+// New creates a Router with the default JSON BodyCodec registered.
+func New() *Router {
+	return &Router{
+		routes:     map[string]map[string]*Operation{},
+		codecs:     newCodecRegistry(),
+		validators: newValidatorRegistry(),
+	}
+}
+
+// ServeHTTP dispatches req to the operation registered for its path and
+// method, or responds 404 if none matches.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	methods, ok := r.routes[req.URL.Path]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	op, ok := methods[req.Method]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	op.dispatch(w, req)
+}
+
+func (r *Router) addOperation(op *Operation) {
+	methods, ok := r.routes[op.resource.path]
+	if !ok {
+		methods = map[string]*Operation{}
+		r.routes[op.resource.path] = methods
+	}
+
+	methods[op.method] = op
+	r.operations = append(r.operations, op)
+}
@@ -0,0 +1,12 @@
+package huma
+
+// newTestRouter creates a Router with the MessagePack codec registered
+// alongside the default JSON one (so codec-parameterized tests like
+// TestRawBody and TestInvalidFieldAcrossCodecs exercise both), and no
+// extra validators or context factory, suitable as a starting point for
+// tests that register their own.
+func newTestRouter() *Router {
+	app := New()
+	RegisterMsgpackCodec(app)
+	return app
+}
@@ -0,0 +1,80 @@
+package huma
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// Sanitizer is an optional interface that a request body (or any struct
+// nested within it) may implement to clean up user input after
+// decoding/parsing but before JSON Schema validation and before `Resolve`
+// runs. It's the right place to trim whitespace, lowercase enums, strip
+// control characters, coerce time zones on `time.Time` fields, or
+// normalize IDs, mirroring the `Resolve(ctx, r)` pattern used by
+// `Dep1`/`Dep2` in resolver_test.go.
+//
+// Errors appended via `ctx.AddError` from within `Sanitize` surface in the
+// same 422 `ErrorModel` as schema validation and resolver errors, so
+// callers get one consolidated failure response instead of a
+// sanitize/validate/resolve ping-pong.
+type Sanitizer interface {
+	Sanitize(ctx Context, r *http.Request)
+}
+
+// runSanitizers recursively walks value looking for anything implementing
+// `Sanitizer` and invokes it before validation, exactly like the resolver
+// recursion in `runResolvers`: pointers and interfaces are dereferenced,
+// slices/arrays and maps are walked element by element, and structs are
+// sanitized and then recursed into field by field so nested shapes like
+// `Dep2.Foo map[string][]*Dep1` are fully covered. location tracks the path
+// to value, and any errors a nested `Sanitize` call adds via `AddError` are
+// prefixed with that path via `prefixNewErrorLocations`, so a `Sanitize`
+// method can set a bare field-relative Location and still have it surface
+// with the full path in the response.
+func runSanitizers(ctx Context, r *http.Request, value reflect.Value, location string) {
+	if !value.IsValid() {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		runSanitizers(ctx, r, value.Elem(), location)
+		return
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			runSanitizers(ctx, r, value.Index(i), fmt.Sprintf("%s[%d]", location, i))
+		}
+		return
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			runSanitizers(ctx, r, value.MapIndex(key), location+"."+mapKeyString(key))
+		}
+		return
+	case reflect.Struct:
+		// Handled below, then recursed into.
+	default:
+		return
+	}
+
+	if value.CanAddr() {
+		if s, ok := value.Addr().Interface().(Sanitizer); ok {
+			before := len(ctx.Errors())
+			s.Sanitize(ctx, r)
+			prefixNewErrorLocations(ctx, before, location)
+		}
+	} else if s, ok := value.Interface().(Sanitizer); ok {
+		before := len(ctx.Errors())
+		s.Sanitize(ctx, r)
+		prefixNewErrorLocations(ctx, before, location)
+	}
+
+	t := value.Type()
+	for i := 0; i < value.NumField(); i++ {
+		name, _ := jsonFieldName(t.Field(i))
+		runSanitizers(ctx, r, value.Field(i), location+"."+name)
+	}
+}
@@ -0,0 +1,142 @@
+package huma
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonFieldName returns the JSON name a struct field is encoded/decoded
+// under, and whether it's optional (its `json` tag carries `omitempty`).
+func jsonFieldName(field reflect.StructField) (name string, omitempty bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	for _, p := range parts[1:] {
+		if p == "omitempty" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty
+}
+
+// mapKeyString renders a map key for use in an error location, e.g.
+// `body.foo.a` for a `map[string]...` or `body.foo.1` for a `map[int]...`.
+// Falls back to `fmt.Sprint` for key kinds that aren't strings or
+// fixed-width integers, so it never panics regardless of how the map is
+// keyed.
+func mapKeyString(key reflect.Value) string {
+	switch key.Kind() {
+	case reflect.String:
+		return key.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10)
+	default:
+		return fmt.Sprint(key.Interface())
+	}
+}
+
+// requiredFieldErrors reports every field of t that is required (its
+// `json` tag has no `omitempty`) but missing from the decoded raw body.
+func requiredFieldErrors(raw map[string]interface{}, t reflect.Type, location string) []*ErrorDetail {
+	var errs []*ErrorDetail
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		name, omitempty := jsonFieldName(field)
+		if name == "-" || omitempty {
+			continue
+		}
+
+		if _, ok := raw[name]; !ok {
+			errs = append(errs, &ErrorDetail{
+				Message:  "required value is missing",
+				Location: location + "." + name,
+			})
+		}
+	}
+
+	return errs
+}
+
+// schemaConstraintErrors checks the simple JSON Schema keyword struct tags
+// this package supports (currently `minimum`) against value's current
+// field values, which is assumed to be a struct.
+func schemaConstraintErrors(value reflect.Value, location string) []*ErrorDetail {
+	var errs []*ErrorDetail
+	t := value.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name, _ := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		min := field.Tag.Get("minimum")
+		if min == "" {
+			continue
+		}
+
+		minVal, err := strconv.ParseFloat(min, 64)
+		if err != nil {
+			continue
+		}
+
+		actual, ok := numericValue(value.Field(i))
+		if !ok || actual >= minVal {
+			continue
+		}
+
+		errs = append(errs, &ErrorDetail{
+			Message:  fmt.Sprintf("Must be greater than or equal to %s", trimFloat(minVal)),
+			Location: location + "." + name,
+			Value:    value.Field(i).Interface(),
+		})
+	}
+
+	return errs
+}
+
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// trimFloat renders a float without a trailing ".0" for whole numbers, so
+// a `minimum:"5"` tag reads "Must be greater than or equal to 5" rather
+// than "...to 5.0".
+func trimFloat(f float64) string {
+	if f == float64(int64(f)) {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
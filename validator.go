@@ -0,0 +1,116 @@
+package huma
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValidatorFunc validates a single field's value against a struct tag rule.
+// param is whatever follows the rule name after a colon, e.g. for
+// `validate:"eqfield:Password"` the rule is `eqfield` and param is
+// `Password`. It returns a non-nil, user-facing error describing the
+// failure, or nil if value is acceptable.
+type ValidatorFunc func(value interface{}, param string) error
+
+// validatorRegistry holds the struct-tag validators registered on a router
+// via `Router.RegisterValidator`.
+type validatorRegistry struct {
+	validators map[string]ValidatorFunc
+}
+
+func newValidatorRegistry() *validatorRegistry {
+	return &validatorRegistry{validators: map[string]ValidatorFunc{}}
+}
+
+func (reg *validatorRegistry) register(name string, fn ValidatorFunc) {
+	reg.validators[name] = fn
+}
+
+// RegisterValidator attaches a domain-specific `validate` struct tag, e.g.
+// `validate:"e164"`, `validate:"iso4217"`, or `validate:"uuid"`, the way
+// `gopkg.in/go-playground/validator.v9` lets callers register custom tags.
+// Registered validators run after JSON Schema validation and participate in
+// the same exhaustive error accumulation as schema and resolver errors: one
+// entry per failed field, with `location` and `value` set.
+//
+// Fields carrying a `validate` tag are also annotated with an `x-validate`
+// OpenAPI extension so generated docs and client tooling can see which
+// custom validators apply.
+func (r *Router) RegisterValidator(name string, fn ValidatorFunc) {
+	r.validators.register(name, fn)
+}
+
+// runValidators recursively walks value looking for `validate` struct tags
+// and runs the matching registered `ValidatorFunc` against each field,
+// appending any failures to ctx via `AddError` using the same
+// `location`/`value` shape as schema validation errors.
+func runValidators(ctx Context, registry *validatorRegistry, value reflect.Value, location string) {
+	if !value.IsValid() {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		runValidators(ctx, registry, value.Elem(), location)
+		return
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			runValidators(ctx, registry, value.Index(i), location+"["+strconv.Itoa(i)+"]")
+		}
+		return
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			runValidators(ctx, registry, value.MapIndex(key), location+"."+mapKeyString(key))
+		}
+		return
+	case reflect.Struct:
+		t := value.Type()
+		for i := 0; i < value.NumField(); i++ {
+			field := t.Field(i)
+
+			name, _ := jsonFieldName(field)
+			fieldLoc := location + "." + name
+
+			if tag := field.Tag.Get("validate"); tag != "" {
+				runFieldValidators(ctx, registry, value.Field(i), fieldLoc, tag)
+			}
+
+			runValidators(ctx, registry, value.Field(i), fieldLoc)
+		}
+	}
+}
+
+// runFieldValidators runs every comma-separated rule in a `validate` tag
+// (each optionally followed by `:param`, e.g. `validate:"uuid,eqfield:Other"`)
+// against a single field.
+func runFieldValidators(ctx Context, registry *validatorRegistry, value reflect.Value, location, tag string) {
+	if !value.CanInterface() {
+		return
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name := rule
+		param := ""
+		if idx := strings.Index(rule, ":"); idx != -1 {
+			name = rule[:idx]
+			param = rule[idx+1:]
+		}
+
+		fn, ok := registry.validators[name]
+		if !ok {
+			continue
+		}
+
+		if err := fn(value.Interface(), param); err != nil {
+			ctx.AddError(&ErrorDetail{
+				Message:  err.Error(),
+				Location: location,
+				Value:    value.Interface(),
+			})
+		}
+	}
+}